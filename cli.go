@@ -3,14 +3,18 @@ package cli
 import (
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
+var durationType = reflect.TypeOf(time.Duration(0))
+
 var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
 var matchAllCap = regexp.MustCompile("([a-z0-9])([A-Z])")
 
@@ -26,44 +30,210 @@ type optState int
 //OptStates
 const (
 	OptStateUntouched optState = iota
-	OptStateFlagPassed
+	OptStateDefault
+	OptStateFileLoaded
 	OptStateEnvPassed
+	OptStateFlagPassed
 )
 
-// Opt helps gather value from cli flag or env var
+// Opt helps gather value from cli flag, config file, or env var
 type Opt struct {
 	Name        string
+	Short       string
 	Description string
 	Value       string
 	State       optState
-	SetFunc     func(string)
+	// SetFunc parses and applies a single string value to the reflected
+	// field for the given stage. Slice/map SetFuncs use the stage to decide
+	// whether to replace the field's prior contents (a new stage, e.g. a
+	// flag overriding a default) or accumulate into them (the same stage
+	// applying more than once, e.g. a repeated `-tag` flag).
+	SetFunc func(value string, state optState) error
 	Required    bool
+	// Choices, if non-empty, restricts Set/Load to one of these values.
+	Choices []string
+	// EnvNames overrides the env var name(s) a Provider looks up for this
+	// opt; checked in order, first match wins. Empty means "use the
+	// provider's default derivation from Name".
+	EnvNames []string
+	// Default and HasDefault record the `default` tag value, if any, for
+	// display in generated help.
+	Default    string
+	HasDefault bool
+	// kind is the reflected field's kind, used only to tell flag.FlagSet
+	// whether this opt is a boolean flag (see IsBoolFlag).
+	kind reflect.Kind
 }
 
 func (opt *Opt) String() string {
 	return opt.Value
 }
 
+// IsBoolFlag reports whether opt is backed by a bool field, letting
+// flag.FlagSet treat it as a boolean flag so `-flag` alone (without
+// `=true`) is accepted, matching the standard library's own bool flags.
+func (opt *Opt) IsBoolFlag() bool {
+	return opt.kind == reflect.Bool
+}
+
 // Set value during 'flag.Parse'
 func (opt *Opt) Set(flagval string) error {
-	opt.SetFunc(flagval)
-	opt.Value = flagval
-	opt.State = OptStateFlagPassed
+	return opt.apply(flagval, OptStateFlagPassed)
+}
+
+// Load applies a value to the opt from a non-flag source (a config file,
+// an env var, or a default), recording which stage supplied it.
+func (opt *Opt) Load(value string, state optState) error {
+	return opt.apply(value, state)
+}
+
+func (opt *Opt) apply(value string, state optState) error {
+	if err := opt.validateChoice(value); err != nil {
+		return err
+	}
+	if err := opt.SetFunc(value, state); err != nil {
+		return err
+	}
+	opt.Value = value
+	opt.State = state
 	return nil
 }
 
+func (opt *Opt) validateChoice(value string) error {
+	if len(opt.Choices) == 0 {
+		return nil
+	}
+	for _, choice := range opt.Choices {
+		if value == choice {
+			return nil
+		}
+	}
+	return fmt.Errorf("opt '%s': '%s' is not one of %s", opt.Name, value, strings.Join(opt.Choices, "|"))
+}
+
+// setFuncFor builds the per-kind parser used to populate a reflected field
+// from a single string value, as seen from either a flag or an env var. The
+// returned func is also handed the stage (optState) the value came from: a
+// slice/map SetFunc replaces the field's prior contents the first time it
+// sees a new stage (so a flag fully overrides a default, rather than
+// appending to it), but accumulates across repeated calls within the same
+// stage (so repeated `-tag a -tag b` flags still build up a list).
+func setFuncFor(ref *reflected) (func(string, optState) error, error) {
+	value := ref.Value
+	switch {
+	case value.Kind() == reflect.String:
+		return func(s string, _ optState) error {
+			value.SetString(s)
+			return nil
+		}, nil
+	case value.Kind() == reflect.Bool:
+		return func(s string, _ optState) error {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return fmt.Errorf("'%s': invalid bool value '%s'", ref.Field.Name, s)
+			}
+			value.SetBool(b)
+			return nil
+		}, nil
+	case value.Type() == durationType:
+		return func(s string, _ optState) error {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return fmt.Errorf("'%s': invalid duration value '%s'", ref.Field.Name, s)
+			}
+			value.SetInt(int64(d))
+			return nil
+		}, nil
+	case value.Kind() == reflect.Int, value.Kind() == reflect.Int8, value.Kind() == reflect.Int16,
+		value.Kind() == reflect.Int32, value.Kind() == reflect.Int64:
+		return func(s string, _ optState) error {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("'%s': invalid int value '%s'", ref.Field.Name, s)
+			}
+			value.SetInt(i)
+			return nil
+		}, nil
+	case value.Kind() == reflect.Uint, value.Kind() == reflect.Uint8, value.Kind() == reflect.Uint16,
+		value.Kind() == reflect.Uint32, value.Kind() == reflect.Uint64:
+		return func(s string, _ optState) error {
+			u, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("'%s': invalid uint value '%s'", ref.Field.Name, s)
+			}
+			value.SetUint(u)
+			return nil
+		}, nil
+	case value.Kind() == reflect.Float32, value.Kind() == reflect.Float64:
+		return func(s string, _ optState) error {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return fmt.Errorf("'%s': invalid float value '%s'", ref.Field.Name, s)
+			}
+			value.SetFloat(f)
+			return nil
+		}, nil
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String:
+		lastState := OptStateUntouched
+		return func(s string, state optState) error {
+			if state != lastState {
+				value.Set(reflect.MakeSlice(value.Type(), 0, 0))
+				lastState = state
+			}
+			value.Set(reflect.AppendSlice(value, reflect.ValueOf(strings.Split(s, ","))))
+			return nil
+		}, nil
+	case value.Kind() == reflect.Map && value.Type().Key().Kind() == reflect.String && value.Type().Elem().Kind() == reflect.String:
+		lastState := OptStateUntouched
+		return func(s string, state optState) error {
+			if value.IsNil() || state != lastState {
+				value.Set(reflect.MakeMap(value.Type()))
+				lastState = state
+			}
+			for _, pair := range strings.Split(s, ",") {
+				k, v, ok := strings.Cut(pair, "=")
+				if !ok {
+					return fmt.Errorf("'%s': invalid map entry '%s', expected key=val", ref.Field.Name, pair)
+				}
+				value.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(v))
+			}
+			return nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("'%s': unsupported opt type %s", ref.Field.Name, value.Type())
+	}
+}
+
 // Flags container for cmd flags
 type Flags map[string]*Opt
 
 // Cmd handles cli commands and subcommands
 type Cmd struct {
-	ExecutedAs string
-	Name       string
-	Args       []string
-	Opts       interface{}
-	SubCmds    []Cmd
-	ParsedOpts []*Opt
-	Run        func() error
+	ExecutedAs  string
+	Name        string
+	Description string
+	Args        []string
+	Opts        interface{}
+	SubCmds     []Cmd
+	ParsedOpts  []*Opt
+	// Providers populates Opts, in order, before flags are parsed; each
+	// later provider overrides values set by an earlier one. Flags always
+	// take precedence over every provider. When nil, Init builds the
+	// default chain itself: a file Provider (picked by FileProvider based
+	// on its extension) if a config path was given via a --config flag or
+	// a $<NAME>_CONFIG env var, followed by NewEnvProvider("").
+	Providers []Provider
+	Run       func() error
+	// ExitHandler, if set, computes the process exit code for an error
+	// returned while resolving or running this command; Main uses it
+	// instead of the default (print the error, exit 1). A subcommand
+	// without its own ExitHandler falls back to the nearest ancestor's.
+	ExitHandler func(error) int
+	// OnUsageError, if set, is called with any usage-class error (a
+	// missing/invalid flag, an unknown subcommand, ErrUsage) before it is
+	// returned, e.g. to log it or print cmd's own Usage. A subcommand
+	// without its own OnUsageError falls back to the nearest ancestor's.
+	OnUsageError func(error)
 }
 
 // AddSubCmd ...
@@ -101,41 +271,133 @@ func reflectStruct(i interface{}) []*reflected {
 	})
 }
 
-// Init bind and parse flags
-func (cmd Cmd) Init(args []string) error {
-	if cmd.Opts == nil {
-		return nil
+// describeOpts reflects cmd.Opts into Opt metadata and posSpecs. It is pure
+// introspection: it never writes into the live cmd.Opts struct, so it's
+// safe to call repeatedly (e.g. from Usage or Completion as well as Init)
+// without side effects. It is the single source of flag metadata shared by
+// Init (which registers it on a flag.FlagSet and applies defaults) and
+// Usage/Completion (which only need it for display), so generated help can
+// never drift from what Init actually parses.
+func (cmd Cmd) describeOpts() ([]*Opt, []*posSpec, error) {
+	refs := reflectStruct(cmd.Opts)
+	posSpecs, err := positionalSpecs(refs)
+	if err != nil {
+		return nil, nil, err
 	}
-	flags := flag.NewFlagSet(cmd.Name, flag.ExitOnError)
-	for _, ref := range reflectStruct(cmd.Opts) {
+	isPositional := map[string]bool{}
+	for _, spec := range posSpecs {
+		isPositional[spec.FieldName] = true
+	}
+
+	var opts []*Opt
+	for _, ref := range refs {
+		if isPositional[ref.Field.Name] {
+			continue
+		}
+		setFunc, err := setFuncFor(ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		name := ToSnakeCase(ref.Field.Name)
+		if nameTag, ok := ref.Field.Tag.Lookup("name"); ok {
+			name = nameTag
+		}
 		opt := &Opt{
-			Name:        ToSnakeCase(ref.Field.Name),
+			Name:        name,
+			Short:       ref.Field.Tag.Get("short"),
 			Description: ref.Field.Tag.Get("desc"),
-			SetFunc:     ref.Value.SetString,
-			Required:    false,
+			SetFunc:     setFunc,
+			kind:        ref.Value.Kind(),
 		}
 		if requiredTag, ok := ref.Field.Tag.Lookup("required"); ok {
 			required, err := strconv.ParseBool(requiredTag)
 			if err != nil {
-				return err
+				return nil, nil, err
 			}
 			opt.Required = required
 		}
-		cmd.ParsedOpts = append(cmd.ParsedOpts, opt)
+		if choicesTag, ok := ref.Field.Tag.Lookup("choices"); ok {
+			opt.Choices = strings.Split(choicesTag, "|")
+		}
+		if envTag, ok := ref.Field.Tag.Lookup("env"); ok {
+			opt.EnvNames = strings.Split(envTag, ",")
+		}
+		if defaultTag, ok := ref.Field.Tag.Lookup("default"); ok {
+			opt.Default = defaultTag
+			opt.HasDefault = true
+		}
+		opts = append(opts, opt)
+	}
+	return opts, posSpecs, nil
+}
+
+// Init bind and parse flags
+func (cmd Cmd) Init(args []string) error {
+	if cmd.Opts == nil {
+		return nil
+	}
+	opts, posSpecs, err := cmd.describeOpts()
+	if err != nil {
+		return err
+	}
+	cmd.ParsedOpts = opts
+	for _, opt := range opts {
+		if opt.HasDefault {
+			if err := opt.Load(opt.Default, OptStateDefault); err != nil {
+				return err
+			}
+		}
+	}
+
+	flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	hasConfigOpt := false
+	for _, opt := range opts {
 		flags.Var(opt, opt.Name, opt.Description)
+		if opt.Short != "" {
+			flags.Var(opt, opt.Short, opt.Description)
+		}
+		if opt.Name == "config" {
+			hasConfigOpt = true
+		}
 	}
-	flags.Parse(args)
-	log.Printf("remaining args: %v\n", flags.Args())
-	for _, opt := range cmd.ParsedOpts {
-		if opt.State != OptStateFlagPassed {
-			if envval, ok := os.LookupEnv(strings.ToUpper(opt.Name)); ok {
-				opt.SetFunc(envval)
-				opt.Value = envval
-				opt.State = OptStateEnvPassed
+	var configFlagVal string
+	if !hasConfigOpt {
+		flags.StringVar(&configFlagVal, "config", "", "path to a config file (json/yaml/toml/env)")
+	}
+
+	providers := cmd.Providers
+	if providers == nil {
+		providers = []Provider{NewEnvProvider("")}
+		if path := resolveConfigPath(args, cmd.Name); path != "" {
+			fileProvider, err := FileProvider(path)
+			if err != nil {
+				return err
 			}
+			providers = []Provider{fileProvider, NewEnvProvider("")}
+		}
+	}
+	for _, provider := range providers {
+		if err := provider.Load(cmd.ParsedOpts); err != nil {
+			return err
 		}
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return &ErrParseFlag{Name: parseFlagName(err), Cause: err}
+	}
+	remaining := flags.Args()
+	cmd.Args = remaining
+	if len(posSpecs) > 0 {
+		if err := bindPositional(posSpecs, remaining); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("remaining args: %v\n", remaining)
+	}
+	for _, opt := range cmd.ParsedOpts {
 		if opt.State == OptStateUntouched && opt.Required {
-			return fmt.Errorf("opt '%s' is required but not passed", opt.Name)
+			return &ErrMissingRequired{Name: opt.Name}
 		}
 	}
 	return nil
@@ -151,25 +413,82 @@ func (cmd Cmd) SubCmd(subcmdname string) (*Cmd, bool) {
 	return nil, false
 }
 
+func isHelpFlag(arg string) bool {
+	return arg == "-h" || arg == "--help"
+}
+
 // Execute given command
 func (cmd Cmd) Execute() error {
-	cmd.ExecutedAs = os.Args[0]
+	err, _ := cmd.execute(os.Args[0], os.Args[1:])
+	return err
+}
+
+// Main runs Execute and, on error, calls the resolved ExitHandler (falling
+// back through ancestor commands to a default that prints the error and
+// returns 1) and exits the process with its result. Use Execute directly
+// when the caller wants to handle the error itself instead of exiting.
+func (cmd Cmd) Main() {
+	err, handler := cmd.execute(os.Args[0], os.Args[1:])
+	if err == nil {
+		return
+	}
+	os.Exit(handler(err))
+}
+
+func defaultExitHandler(err error) int {
+	fmt.Fprintln(os.Stderr, err)
+	return 1
+}
+
+// execute walks args through cmd's subcommand tree, invoking the nearest
+// ancestor's OnUsageError hook for a usage-class failure, then Inits and
+// Runs the resolved leaf command. executedAs seeds Usage's invocation
+// line. It also returns the ExitHandler resolved along that walk (falling
+// back to a parent's when a subcommand doesn't define its own), for Main.
+func (cmd Cmd) execute(executedAs string, args []string) (error, func(error) int) {
+	cmd.ExecutedAs = executedAs
 	targetCmd := &cmd
-	args := os.Args[1:]
-	for len(targetCmd.SubCmds) > 0 {
+	handler := defaultExitHandler
+	if cmd.ExitHandler != nil {
+		handler = cmd.ExitHandler
+	}
+	onUsageError := cmd.OnUsageError
+
+	fail := func(err error) (error, func(error) int) {
+		if onUsageError != nil && isUsageError(err) {
+			onUsageError(err)
+		}
+		return err, handler
+	}
+
+	for {
+		if targetCmd.ExitHandler != nil {
+			handler = targetCmd.ExitHandler
+		}
+		if targetCmd.OnUsageError != nil {
+			onUsageError = targetCmd.OnUsageError
+		}
+		if len(args) > 0 && isHelpFlag(args[0]) {
+			fmt.Print(targetCmd.Usage())
+			return nil, handler
+		}
+		if len(targetCmd.SubCmds) == 0 {
+			break
+		}
 		if len(args) < 1 {
-			return fmt.Errorf("%s: not engough arguments", targetCmd.Name)
+			return fail(&ErrUsage{Message: fmt.Sprintf("%s: not engough arguments", targetCmd.Name)})
 		}
 		subCmdName := args[0]
 		subCmd, ok := targetCmd.SubCmd(subCmdName)
 		if !ok {
-			return fmt.Errorf("'%s' is not a %s command", subCmdName, targetCmd.Name)
+			return fail(&ErrUnknownSubcommand{Parent: targetCmd.Name, Given: subCmdName})
 		}
+		subCmd.ExecutedAs = targetCmd.ExecutedAs + " " + subCmdName
 		targetCmd = subCmd
 		args = args[1:]
 	}
 	if err := targetCmd.Init(args); err != nil {
-		return err
+		return fail(err)
 	}
-	return targetCmd.Run()
+	return targetCmd.Run(), handler
 }
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInitBoolFlagStandalone(t *testing.T) {
+	opts := &struct {
+		Verbose bool
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"-verbose"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if !opts.Verbose {
+		t.Fatalf("expected Verbose=true after bare -verbose, got false")
+	}
+}
+
+func TestSetFuncForKinds(t *testing.T) {
+	opts := &struct {
+		Count   int
+		Ratio   float64
+		Timeout time.Duration
+		Tags    []string
+		Labels  map[string]string
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	err := cmd.Init([]string{
+		"-count", "3",
+		"-ratio", "1.5",
+		"-timeout", "250ms",
+		"-tags", "a,b,c",
+		"-labels", "k1=v1,k2=v2",
+	})
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.Count != 3 {
+		t.Errorf("Count = %d, want 3", opts.Count)
+	}
+	if opts.Ratio != 1.5 {
+		t.Errorf("Ratio = %v, want 1.5", opts.Ratio)
+	}
+	if opts.Timeout != 250*time.Millisecond {
+		t.Errorf("Timeout = %v, want 250ms", opts.Timeout)
+	}
+	if len(opts.Tags) != 3 || opts.Tags[0] != "a" || opts.Tags[2] != "c" {
+		t.Errorf("Tags = %v, want [a b c]", opts.Tags)
+	}
+	if opts.Labels["k1"] != "v1" || opts.Labels["k2"] != "v2" || len(opts.Labels) != 2 {
+		t.Errorf("Labels = %v, want map[k1:v1 k2:v2]", opts.Labels)
+	}
+}
+
+func TestSetFuncForMapRejectsMalformedEntry(t *testing.T) {
+	opts := &struct {
+		Labels map[string]string
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"-labels", "not-a-pair"}); err == nil {
+		t.Fatalf("expected error for malformed map entry, got nil")
+	}
+}
+
+func TestFlagFullyOverridesSliceAndMapDefault(t *testing.T) {
+	opts := &struct {
+		Tags   []string          `default:"a,b"`
+		Labels map[string]string `default:"a=1,b=2"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"-tags", "c,d", "-labels", "c=3"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	wantTags := []string{"c", "d"}
+	if len(opts.Tags) != len(wantTags) || opts.Tags[0] != wantTags[0] || opts.Tags[1] != wantTags[1] {
+		t.Fatalf("Tags = %v, want %v (flag should replace default, not append to it)", opts.Tags, wantTags)
+	}
+	wantLabels := map[string]string{"c": "3"}
+	if !reflect.DeepEqual(opts.Labels, wantLabels) {
+		t.Fatalf("Labels = %v, want %v (flag should replace default, not merge with it)", opts.Labels, wantLabels)
+	}
+}
+
+func TestRepeatedFlagWithinSameStageAccumulates(t *testing.T) {
+	opts := &struct {
+		Tags []string
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"-tags", "a", "-tags", "b"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	want := []string{"a", "b"}
+	if len(opts.Tags) != len(want) || opts.Tags[0] != want[0] || opts.Tags[1] != want[1] {
+		t.Fatalf("Tags = %v, want %v (repeated flags in the same stage should accumulate)", opts.Tags, want)
+	}
+}
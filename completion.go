@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// completionData is the flag/subcommand metadata for a single Cmd, used to
+// generate shell completion scripts.
+type completionData struct {
+	SubCmds []string
+	Flags   []string
+}
+
+func (cmd Cmd) completionData() completionData {
+	var data completionData
+	for _, sub := range cmd.SubCmds {
+		data.SubCmds = append(data.SubCmds, sub.Name)
+	}
+	if cmd.Opts != nil {
+		if opts, _, err := cmd.describeOpts(); err == nil {
+			for _, opt := range opts {
+				data.Flags = append(data.Flags, "--"+opt.Name)
+				if opt.Short != "" {
+					data.Flags = append(data.Flags, "-"+opt.Short)
+				}
+			}
+		}
+	}
+	return data
+}
+
+// Completion renders a shell completion script for cmd in the given shell
+// ("bash", "zsh", or "fish"), enumerating subcommands and flags from the
+// same reflection metadata Init uses to register them.
+func (cmd Cmd) Completion(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return cmd.bashCompletion(), nil
+	case "zsh":
+		return cmd.zshCompletion(), nil
+	case "fish":
+		return cmd.fishCompletion(), nil
+	default:
+		return "", fmt.Errorf("unsupported completion shell '%s'", shell)
+	}
+}
+
+func (cmd Cmd) bashCompletion() string {
+	data := cmd.completionData()
+	words := append(append([]string{}, data.SubCmds...), data.Flags...)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# bash completion for %s\n", cmd.Name)
+	fmt.Fprintf(&b, "_%s_completions() {\n", cmd.Name)
+	b.WriteString("  local cur\n")
+	b.WriteString("  cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	fmt.Fprintf(&b, "  COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(words, " "))
+	b.WriteString("}\n")
+	fmt.Fprintf(&b, "complete -F _%s_completions %s\n", cmd.Name, cmd.Name)
+	return b.String()
+}
+
+func (cmd Cmd) zshCompletion() string {
+	data := cmd.completionData()
+	words := append(append([]string{}, data.SubCmds...), data.Flags...)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n\n", cmd.Name)
+	fmt.Fprintf(&b, "_%s() {\n", cmd.Name)
+	fmt.Fprintf(&b, "  local -a words=(%s)\n", strings.Join(quoteAll(words), " "))
+	b.WriteString("  _describe 'command' words\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "compdef _%s %s\n", cmd.Name, cmd.Name)
+	return b.String()
+}
+
+func (cmd Cmd) fishCompletion() string {
+	data := cmd.completionData()
+
+	var b strings.Builder
+	for _, sub := range data.SubCmds {
+		fmt.Fprintf(&b, "complete -c %s -n __fish_use_subcommand -a %s\n", cmd.Name, sub)
+	}
+	for _, f := range data.Flags {
+		if long := strings.TrimPrefix(f, "--"); long != f {
+			fmt.Fprintf(&b, "complete -c %s -l %s\n", cmd.Name, long)
+			continue
+		}
+		short := strings.TrimPrefix(f, "-")
+		fmt.Fprintf(&b, "complete -c %s -s %s\n", cmd.Name, short)
+	}
+	return b.String()
+}
+
+func quoteAll(words []string) []string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = "'" + w + "'"
+	}
+	return quoted
+}
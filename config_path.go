@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"os"
+	"strings"
+)
+
+// scanConfigFlag looks for a "--config"/"-config" flag in args, in either
+// "--config value" or "--config=value" form, without fully parsing args —
+// the config path has to be known before the Provider chain that supplies
+// the rest of cmd.Opts's values is built and run.
+func scanConfigFlag(args []string) (string, bool) {
+	for i, arg := range args {
+		switch {
+		case arg == "--config" || arg == "-config":
+			if i+1 < len(args) {
+				return args[i+1], true
+			}
+		case strings.HasPrefix(arg, "--config="):
+			return strings.TrimPrefix(arg, "--config="), true
+		case strings.HasPrefix(arg, "-config="):
+			return strings.TrimPrefix(arg, "-config="), true
+		}
+	}
+	return "", false
+}
+
+// resolveConfigPath determines the config file path for cmd: a --config
+// flag takes precedence over the $<NAME>_CONFIG env var, where NAME is
+// cmd's name upper-cased (e.g. "myapp" -> MYAPP_CONFIG).
+func resolveConfigPath(args []string, cmdName string) string {
+	if path, ok := scanConfigFlag(args); ok {
+		return path
+	}
+	envName := strings.ToUpper(strings.ReplaceAll(cmdName, "-", "_")) + "_CONFIG"
+	return os.Getenv(envName)
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"os"
+	"strings"
+)
+
+// envKeyer derives the environment-variable-style key for an opt, shared by
+// EnvProvider and DotenvProvider so both match keys the same way.
+type envKeyer struct {
+	Prefix string
+}
+
+// keys returns the candidate env-var-style keys for opt, in lookup order.
+// An opt with an `env` tag overrides the derived name entirely.
+func (k envKeyer) keys(opt *Opt) []string {
+	if len(opt.EnvNames) > 0 {
+		return opt.EnvNames
+	}
+	name := strings.ToUpper(strings.ReplaceAll(opt.Name, "-", "_"))
+	if k.Prefix == "" {
+		return []string{name}
+	}
+	return []string{strings.ToUpper(k.Prefix) + "_" + name}
+}
+
+// EnvProvider populates opts from process environment variables, matching
+// each opt's upper-cased, underscore-separated name, optionally joined to
+// a Prefix (e.g. name "foo-bar" with prefix "myapp" looks up MYAPP_FOO_BAR).
+type EnvProvider struct {
+	envKeyer
+}
+
+// NewEnvProvider builds an EnvProvider; prefix may be empty to match bare
+// env var names.
+func NewEnvProvider(prefix string) *EnvProvider {
+	return &EnvProvider{envKeyer{Prefix: prefix}}
+}
+
+// Load implements Provider.
+func (p *EnvProvider) Load(opts []*Opt) error {
+	for _, opt := range opts {
+		for _, key := range p.keys(opt) {
+			if val, ok := os.LookupEnv(key); ok {
+				if err := opt.Load(val, OptStateEnvPassed); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
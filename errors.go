@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var flagNameRe = regexp.MustCompile(`-([\w-]+)`)
+
+// parseFlagName does a best-effort extraction of the offending flag's name
+// from one of the standard library flag package's error messages, which
+// don't expose it structurally.
+func parseFlagName(err error) string {
+	if m := flagNameRe.FindStringSubmatch(err.Error()); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// ErrMissingRequired is returned when a required opt or positional arg was
+// never set by a `default` tag, any Provider, flag, or positional binding.
+type ErrMissingRequired struct {
+	Name string
+}
+
+func (e *ErrMissingRequired) Error() string {
+	return fmt.Sprintf("'%s' is required but not passed", e.Name)
+}
+
+// ErrUnknownSubcommand is returned when Execute can't find a subcommand of
+// Parent matching Given.
+type ErrUnknownSubcommand struct {
+	Parent string
+	Given  string
+}
+
+func (e *ErrUnknownSubcommand) Error() string {
+	return fmt.Sprintf("'%s' is not a %s command", e.Given, e.Parent)
+}
+
+// ErrParseFlag wraps a flag-parsing failure with the flag name that caused
+// it, where it could be determined.
+type ErrParseFlag struct {
+	Name  string
+	Cause error
+}
+
+func (e *ErrParseFlag) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("parsing flags: %s", e.Cause)
+	}
+	return fmt.Sprintf("parsing flag '%s': %s", e.Name, e.Cause)
+}
+
+func (e *ErrParseFlag) Unwrap() error {
+	return e.Cause
+}
+
+// ErrUsage is returned for malformed invocations not covered by a more
+// specific error, such as a subcommand path cut short of args.
+type ErrUsage struct {
+	Message string
+}
+
+func (e *ErrUsage) Error() string {
+	return e.Message
+}
+
+// isUsageError reports whether err is one of the package's usage-class
+// errors (a mistake in how the command was invoked), as opposed to a
+// failure resolving its configuration, such as a malformed config file or
+// a Provider's disk I/O error. Used by execute to decide whether to invoke
+// a command's OnUsageError hook.
+func isUsageError(err error) bool {
+	switch err.(type) {
+	case *ErrUsage, *ErrUnknownSubcommand, *ErrMissingRequired, *ErrParseFlag:
+		return true
+	default:
+		return false
+	}
+}
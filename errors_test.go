@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBindPositionalTooManyArgsIsUsageError(t *testing.T) {
+	opts := &struct {
+		First string `pos:"1"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	err := cmd.Init([]string{"a", "b"})
+	if err == nil {
+		t.Fatal("expected error for extra positional argument, got nil")
+	}
+	var usageErr *ErrUsage
+	if !errors.As(err, &usageErr) {
+		t.Fatalf("err = %v (%T), want *ErrUsage", err, err)
+	}
+}
+
+func TestOnUsageErrorFiresForUsageErrorsOnly(t *testing.T) {
+	var hooked error
+	cmd := Cmd{
+		Name: "test",
+		Opts: &struct {
+			First string `pos:"1" required:"true"`
+		}{},
+		OnUsageError: func(err error) { hooked = err },
+	}
+	if err, _ := cmd.execute("test", []string{"a", "b"}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if hooked == nil {
+		t.Fatal("expected OnUsageError to fire for a usage-class error, it did not")
+	}
+
+	hooked = nil
+	cmd = Cmd{
+		Name: "test",
+		Opts: &struct {
+			Name string
+		}{},
+		Providers:    []Provider{erroringProvider{}},
+		OnUsageError: func(err error) { hooked = err },
+	}
+	if err, _ := cmd.execute("test", nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if hooked != nil {
+		t.Fatalf("OnUsageError fired for a non-usage error: %v", hooked)
+	}
+}
+
+type erroringProvider struct{}
+
+func (erroringProvider) Load(opts []*Opt) error {
+	return errors.New("disk exploded")
+}
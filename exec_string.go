@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrUnclosedQuote is returned by SplitArgs when the command line ends
+// while still inside an open quote.
+type ErrUnclosedQuote struct {
+	Quote rune
+}
+
+func (e *ErrUnclosedQuote) Error() string {
+	return fmt.Sprintf("unclosed %c quote", e.Quote)
+}
+
+// SplitArgs tokenizes a shell-style command line the way a shell would
+// before exec'ing: whitespace separates args, single and double quotes
+// group an arg that contains whitespace, a backslash escapes the next
+// character, and a bare "--" is passed through as its own token so it can
+// still act as flag's end-of-flags marker. It does not perform variable
+// expansion or globbing.
+func SplitArgs(cmdline string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	hasToken := false
+	var quote rune
+	escaped := false
+
+	flush := func() {
+		if hasToken {
+			args = append(args, cur.String())
+			cur.Reset()
+			hasToken = false
+		}
+	}
+
+	for _, r := range cmdline {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			hasToken = true
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			hasToken = true
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		default:
+			cur.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, &ErrUnclosedQuote{Quote: quote}
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash with no character to escape")
+	}
+	flush()
+	return args, nil
+}
+
+// ExecuteString tokenizes cmdline with SplitArgs and dispatches it through
+// the same subcommand/opt resolution as Execute. This lets embedders
+// (REPLs, TUIs, test harnesses) invoke commands without going through
+// os.Args; callers wanting completion on a partial command line can call
+// SplitArgs themselves to get the same tokens this method would dispatch.
+func (cmd Cmd) ExecuteString(cmdline string) error {
+	args, err := SplitArgs(cmdline)
+	if err != nil {
+		return err
+	}
+	runErr, _ := cmd.execute(cmd.Name, args)
+	return runErr
+}
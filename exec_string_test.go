@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	cases := []struct {
+		name    string
+		cmdline string
+		want    []string
+	}{
+		{"empty", "", nil},
+		{"simple", "one two three", []string{"one", "two", "three"}},
+		{"extra whitespace", "  one   two\tthree\n", []string{"one", "two", "three"}},
+		{"double quotes group whitespace", `one "two and a half" three`, []string{"one", "two and a half", "three"}},
+		{"single quotes group whitespace", `one 'two and a half' three`, []string{"one", "two and a half", "three"}},
+		{"adjacent quoted and bare", `foo"bar"baz`, []string{"foobarbaz"}},
+		{"escaped space", `one\ two three`, []string{"one two", "three"}},
+		{"escaped quote", `one\"two`, []string{`one"two`}},
+		{"backslash inert in single quotes", `'one\two'`, []string{`one\two`}},
+		{"double dash passed through", `cmd -- --not-a-flag`, []string{"cmd", "--", "--not-a-flag"}},
+		{"empty quoted arg", `""`, []string{""}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := SplitArgs(c.cmdline)
+			if err != nil {
+				t.Fatalf("SplitArgs(%q): %v", c.cmdline, err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("SplitArgs(%q) = %#v, want %#v", c.cmdline, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSplitArgsUnclosedQuote(t *testing.T) {
+	_, err := SplitArgs(`one "two`)
+	if err == nil {
+		t.Fatal("expected error for unclosed quote, got nil")
+	}
+	var unclosed *ErrUnclosedQuote
+	if !errors.As(err, &unclosed) {
+		t.Fatalf("err = %v (%T), want *ErrUnclosedQuote", err, err)
+	}
+	if unclosed.Quote != '"' {
+		t.Fatalf("Quote = %q, want %q", unclosed.Quote, '"')
+	}
+}
+
+func TestSplitArgsTrailingBackslash(t *testing.T) {
+	if _, err := SplitArgs(`one\`); err == nil {
+		t.Fatal("expected error for trailing backslash, got nil")
+	}
+}
+
+func TestExecuteStringDispatchesLikeExecute(t *testing.T) {
+	opts := &struct {
+		Name string
+	}{}
+	ran := false
+	cmd := Cmd{
+		Name: "test",
+		Opts: opts,
+		Run: func() error {
+			ran = true
+			return nil
+		},
+	}
+	if err := cmd.ExecuteString(`-name "jane doe"`); err != nil {
+		t.Fatalf("ExecuteString: %v", err)
+	}
+	if !ran {
+		t.Fatal("expected Run to be invoked")
+	}
+	if opts.Name != "jane doe" {
+		t.Fatalf("Name = %q, want %q", opts.Name, "jane doe")
+	}
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FileProvider dispatches to JSONProvider, YAMLProvider, TOMLProvider, or
+// DotenvProvider based on path's extension (.json / .yaml,.yml / .toml /
+// .env). Used by Cmd.Init's --config/$APPNAME_CONFIG wiring, and by any
+// other caller that only has a path and not a known format ahead of time.
+func FileProvider(path string) (Provider, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return JSONProvider(path), nil
+	case ".yaml", ".yml":
+		return YAMLProvider(path), nil
+	case ".toml":
+		return TOMLProvider(path), nil
+	case ".env":
+		return NewDotenvProvider(path, ""), nil
+	default:
+		return nil, fmt.Errorf("config file '%s': unrecognized extension '%s'", path, ext)
+	}
+}
+
+// structuredFileProvider loads opts from a config file decoded into a flat
+// map[string]interface{}, matched by opt name. Missing files are treated as
+// empty rather than an error, since a config file is typically optional.
+type structuredFileProvider struct {
+	Path      string
+	Unmarshal func(data []byte, v interface{}) error
+}
+
+// Load implements Provider.
+func (p structuredFileProvider) Load(opts []*Opt) error {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	values := map[string]interface{}{}
+	if err := p.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("parsing config file '%s': %w", p.Path, err)
+	}
+	for _, opt := range opts {
+		raw, ok := values[opt.Name]
+		if !ok {
+			continue
+		}
+		if err := loadStructuredValue(opt, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadStructuredValue(opt *Opt, raw interface{}) error {
+	if list, ok := raw.([]interface{}); ok {
+		parts := make([]string, len(list))
+		for i, item := range list {
+			parts[i] = fmt.Sprint(item)
+		}
+		return opt.Load(strings.Join(parts, ","), OptStateFileLoaded)
+	}
+	return opt.Load(fmt.Sprint(raw), OptStateFileLoaded)
+}
+
+// JSONProvider loads opts from a JSON config file at path, matched by opt
+// name (e.g. {"foo-bar": "baz"} sets the opt named "foo-bar").
+func JSONProvider(path string) Provider {
+	return structuredFileProvider{Path: path, Unmarshal: json.Unmarshal}
+}
+
+// YAMLProvider loads opts from a YAML config file at path, matched by opt
+// name.
+func YAMLProvider(path string) Provider {
+	return structuredFileProvider{Path: path, Unmarshal: yaml.Unmarshal}
+}
+
+// TOMLProvider loads opts from a TOML config file at path, matched by opt
+// name.
+func TOMLProvider(path string) Provider {
+	return structuredFileProvider{
+		Path: path,
+		Unmarshal: func(data []byte, v interface{}) error {
+			return toml.Unmarshal(data, v)
+		},
+	}
+}
+
+// DotenvProvider loads opts from a dotenv-style file (KEY=VALUE per line,
+// blank lines and '#' comments ignored), matched the same way EnvProvider
+// matches process env vars.
+type DotenvProvider struct {
+	Path string
+	envKeyer
+}
+
+// NewDotenvProvider builds a DotenvProvider; prefix may be empty to match
+// bare keys.
+func NewDotenvProvider(path, prefix string) *DotenvProvider {
+	return &DotenvProvider{Path: path, envKeyer: envKeyer{Prefix: prefix}}
+}
+
+// Load implements Provider.
+func (p *DotenvProvider) Load(opts []*Opt) error {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	values := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(val), `"'`)
+	}
+	for _, opt := range opts {
+		for _, key := range p.keys(opt) {
+			if val, ok := values[key]; ok {
+				if err := opt.Load(val, OptStateFileLoaded); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
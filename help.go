@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Usage renders a help screen for cmd: its invocation line, description,
+// positional args, options (with defaults/required markers/choices), and
+// subcommands. It is printed automatically by Execute when -h/--help is
+// passed at any level.
+func (cmd Cmd) Usage() string {
+	var b strings.Builder
+
+	path := cmd.ExecutedAs
+	if path == "" {
+		path = cmd.Name
+	}
+	fmt.Fprintf(&b, "Usage: %s", path)
+	if cmd.Opts != nil {
+		fmt.Fprint(&b, " [options]")
+	}
+
+	var opts []*Opt
+	var posSpecs []*posSpec
+	if cmd.Opts != nil {
+		opts, posSpecs, _ = cmd.describeOpts()
+		for _, spec := range posSpecs {
+			fmt.Fprintf(&b, " %s", posUsageToken(spec))
+		}
+	}
+	if len(cmd.SubCmds) > 0 {
+		fmt.Fprint(&b, " <command>")
+	}
+	b.WriteString("\n")
+
+	if cmd.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", cmd.Description)
+	}
+
+	if len(opts) > 0 {
+		b.WriteString("\nOptions:\n")
+		for _, opt := range opts {
+			fmt.Fprintf(&b, "  %s\n", optUsageLine(opt))
+		}
+	}
+
+	if len(cmd.SubCmds) > 0 {
+		b.WriteString("\nCommands:\n")
+		for _, sub := range cmd.SubCmds {
+			fmt.Fprintf(&b, "  %-16s %s\n", sub.Name, sub.Description)
+		}
+	}
+
+	return b.String()
+}
+
+func posUsageToken(spec *posSpec) string {
+	name := ToSnakeCase(spec.FieldName)
+	switch {
+	case spec.Rest && spec.Required:
+		return fmt.Sprintf("<%s...>", name)
+	case spec.Rest:
+		return fmt.Sprintf("[%s...]", name)
+	case spec.Required:
+		return fmt.Sprintf("<%s>", name)
+	default:
+		return fmt.Sprintf("[%s]", name)
+	}
+}
+
+func optUsageLine(opt *Opt) string {
+	flagName := "--" + opt.Name
+	if opt.Short != "" {
+		flagName = "-" + opt.Short + ", " + flagName
+	}
+	line := flagName
+	if opt.Description != "" {
+		line += "  " + opt.Description
+	}
+
+	var extras []string
+	if opt.Required {
+		extras = append(extras, "required")
+	}
+	if opt.HasDefault {
+		extras = append(extras, fmt.Sprintf("default: %s", opt.Default))
+	}
+	if len(opt.Choices) > 0 {
+		extras = append(extras, fmt.Sprintf("choices: %s", strings.Join(opt.Choices, "|")))
+	}
+	if len(extras) > 0 {
+		line += " (" + strings.Join(extras, ", ") + ")"
+	}
+	return line
+}
@@ -0,0 +1,27 @@
+package cli
+
+import "testing"
+
+func TestUsageDoesNotDuplicateSliceDefaults(t *testing.T) {
+	opts := &struct {
+		Tags []string `default:"a,b"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+
+	// Usage (and Completion) must be pure introspection: calling them
+	// before Init should not apply defaults into the live struct, since
+	// Init applies defaults itself exactly once.
+	cmd.Usage()
+	cmd.Usage()
+	if _, err := cmd.Completion("bash"); err != nil {
+		t.Fatalf("Completion: %v", err)
+	}
+
+	if err := cmd.Init(nil); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	want := []string{"a", "b"}
+	if len(opts.Tags) != len(want) || opts.Tags[0] != want[0] || opts.Tags[1] != want[1] {
+		t.Fatalf("Tags = %v, want %v (default applied more than once?)", opts.Tags, want)
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+type posArity string
+
+const (
+	arityOne  posArity = "1"
+	arityPlus posArity = "+"
+	arityStar posArity = "*"
+)
+
+// posSpec is a positional-argument binding derived from a `pos` struct tag.
+type posSpec struct {
+	FieldName string
+	Rest      bool
+	Index     int // 0-based, meaningful only when !Rest
+	Arity     posArity
+	Required  bool
+	Value     reflect.Value
+	SetFunc   func(value string, state optState) error
+}
+
+// positionalSpecs extracts the `pos`/`arity`-tagged fields of refs, in the
+// order they should consume args: numbered positions first (by position),
+// then a single `pos:"rest"` field last.
+func positionalSpecs(refs []*reflected) ([]*posSpec, error) {
+	var specs []*posSpec
+	for _, ref := range refs {
+		posTag, ok := ref.Field.Tag.Lookup("pos")
+		if !ok {
+			continue
+		}
+		spec := &posSpec{FieldName: ref.Field.Name, Value: ref.Value, Arity: arityOne}
+		if posTag == "rest" {
+			if ref.Value.Kind() != reflect.Slice || ref.Value.Type().Elem().Kind() != reflect.String {
+				return nil, fmt.Errorf("field '%s': pos:\"rest\" requires a []string field", ref.Field.Name)
+			}
+			spec.Rest = true
+			spec.Arity = arityStar
+		} else {
+			n, err := strconv.Atoi(posTag)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("field '%s': invalid pos tag '%s'", ref.Field.Name, posTag)
+			}
+			spec.Index = n - 1
+			setFunc, err := setFuncFor(ref)
+			if err != nil {
+				return nil, err
+			}
+			spec.SetFunc = setFunc
+		}
+		if arityTag, ok := ref.Field.Tag.Lookup("arity"); ok {
+			spec.Arity = posArity(arityTag)
+		}
+		spec.Required = spec.Arity != arityStar
+		if requiredTag, ok := ref.Field.Tag.Lookup("required"); ok {
+			required, err := strconv.ParseBool(requiredTag)
+			if err != nil {
+				return nil, err
+			}
+			spec.Required = required
+		}
+		specs = append(specs, spec)
+	}
+	sort.SliceStable(specs, func(i, j int) bool {
+		if specs[i].Rest != specs[j].Rest {
+			return specs[j].Rest
+		}
+		return specs[i].Index < specs[j].Index
+	})
+	return specs, nil
+}
+
+// bindPositional assigns remaining non-flag args to fields tagged `pos`, in
+// tag order; a `pos:"rest"` field, if present, consumes the variadic tail.
+func bindPositional(specs []*posSpec, args []string) error {
+	idx := 0
+	for _, spec := range specs {
+		if spec.Rest {
+			tail := args[idx:]
+			if len(tail) == 0 && spec.Required {
+				return &ErrMissingRequired{Name: spec.FieldName}
+			}
+			spec.Value.Set(reflect.AppendSlice(spec.Value, reflect.ValueOf(tail)))
+			idx = len(args)
+			continue
+		}
+		if idx >= len(args) {
+			if spec.Required {
+				return &ErrMissingRequired{Name: spec.FieldName}
+			}
+			continue
+		}
+		if err := spec.SetFunc(args[idx], OptStateFlagPassed); err != nil {
+			return err
+		}
+		idx++
+	}
+	if idx < len(args) {
+		return &ErrUsage{Message: fmt.Sprintf("too many positional arguments: %v", args[idx:])}
+	}
+	return nil
+}
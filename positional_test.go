@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPositionalArityOneBindsInOrder(t *testing.T) {
+	opts := &struct {
+		First  string `pos:"1"`
+		Second string `pos:"2"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"a", "b"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.First != "a" || opts.Second != "b" {
+		t.Fatalf("First=%q Second=%q, want a/b", opts.First, opts.Second)
+	}
+}
+
+func TestPositionalArityOneMissingIsRequiredByDefault(t *testing.T) {
+	opts := &struct {
+		First string `pos:"1"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	err := cmd.Init(nil)
+	if err == nil {
+		t.Fatal("expected error for missing required positional arg, got nil")
+	}
+	var missing *ErrMissingRequired
+	if !errors.As(err, &missing) {
+		t.Fatalf("err = %v (%T), want *ErrMissingRequired", err, err)
+	}
+	if missing.Name != "First" {
+		t.Fatalf("Name = %q, want %q", missing.Name, "First")
+	}
+}
+
+func TestPositionalArityStarMakesFieldOptional(t *testing.T) {
+	opts := &struct {
+		First string `pos:"1" arity:"*"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init(nil); err != nil {
+		t.Fatalf("Init: %v (arity:\"*\" should make this field optional)", err)
+	}
+	if opts.First != "" {
+		t.Fatalf("First = %q, want empty when no arg was given", opts.First)
+	}
+}
+
+func TestPositionalArityPlusIsRequiredLikeDefault(t *testing.T) {
+	opts := &struct {
+		First string `pos:"1" arity:"+"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init(nil); err == nil {
+		t.Fatal("expected error for missing arity:\"+\" positional arg, got nil")
+	}
+}
+
+func TestPositionalRestConsumesRemainingArgs(t *testing.T) {
+	opts := &struct {
+		First string   `pos:"1"`
+		Rest  []string `pos:"rest"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"a", "b", "c"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.First != "a" {
+		t.Fatalf("First = %q, want %q", opts.First, "a")
+	}
+	want := []string{"b", "c"}
+	if len(opts.Rest) != len(want) || opts.Rest[0] != want[0] || opts.Rest[1] != want[1] {
+		t.Fatalf("Rest = %v, want %v", opts.Rest, want)
+	}
+}
+
+func TestPositionalRestIsOptionalByDefault(t *testing.T) {
+	opts := &struct {
+		Rest []string `pos:"rest"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init(nil); err != nil {
+		t.Fatalf("Init: %v (pos:\"rest\" should be optional by default)", err)
+	}
+	if len(opts.Rest) != 0 {
+		t.Fatalf("Rest = %v, want empty", opts.Rest)
+	}
+}
+
+func TestPositionalRestRequiredTagErrorsWhenEmpty(t *testing.T) {
+	opts := &struct {
+		Rest []string `pos:"rest" required:"true"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	err := cmd.Init(nil)
+	if err == nil {
+		t.Fatal("expected error for empty required pos:\"rest\", got nil")
+	}
+	var missing *ErrMissingRequired
+	if !errors.As(err, &missing) {
+		t.Fatalf("err = %v (%T), want *ErrMissingRequired", err, err)
+	}
+}
+
+func TestPositionalRestRequiresSliceOfString(t *testing.T) {
+	opts := &struct {
+		Rest string `pos:"rest"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init(nil); err == nil {
+		t.Fatal("expected error for pos:\"rest\" on a non-[]string field, got nil")
+	}
+}
@@ -0,0 +1,10 @@
+package cli
+
+// Provider populates a set of already-reflected Opts from some external
+// source, such as a config file or the environment. Cmd.Init runs the
+// configured Providers, in order, before flags are parsed, so a later
+// Provider in the chain overrides values set by an earlier one; a flag
+// passed on the command line always wins over every Provider.
+type Provider interface {
+	Load(opts []*Opt) error
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInitConfigFlagLoadsJSONFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.json")
+	if err := os.WriteFile(path, []byte(`{"name": "from-file"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &struct {
+		Name string
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"--config", path}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.Name != "from-file" {
+		t.Fatalf("Name = %q, want %q", opts.Name, "from-file")
+	}
+}
+
+func TestInitConfigEnvVarLoadsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.json")
+	if err := os.WriteFile(path, []byte(`{"name": "from-env-config"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_CONFIG", path)
+
+	opts := &struct {
+		Name string
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init(nil); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.Name != "from-env-config" {
+		t.Fatalf("Name = %q, want %q", opts.Name, "from-env-config")
+	}
+}
+
+func TestInitFlagOverridesConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.json")
+	if err := os.WriteFile(path, []byte(`{"name": "from-file"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &struct {
+		Name string
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"--config", path, "-name", "from-flag"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.Name != "from-flag" {
+		t.Fatalf("Name = %q, want %q (flag should win over config file)", opts.Name, "from-flag")
+	}
+}
+
+func TestFileProviderUnrecognizedExtension(t *testing.T) {
+	if _, err := FileProvider("cfg.ini"); err == nil {
+		t.Fatal("expected error for unrecognized extension, got nil")
+	}
+}
+
+func TestConfigFileFullyOverridesSliceDefault(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.json")
+	if err := os.WriteFile(path, []byte(`{"tags": ["c", "d"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &struct {
+		Tags []string `default:"a,b"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"--config", path}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	want := []string{"c", "d"}
+	if len(opts.Tags) != len(want) || opts.Tags[0] != want[0] || opts.Tags[1] != want[1] {
+		t.Fatalf("Tags = %v, want %v (config file should replace default, not append to it)", opts.Tags, want)
+	}
+}
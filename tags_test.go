@@ -0,0 +1,94 @@
+package cli
+
+import "testing"
+
+func TestShortTagRegistersAliasFlag(t *testing.T) {
+	opts := &struct {
+		Verbose bool `short:"v"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"-v"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if !opts.Verbose {
+		t.Fatal("expected Verbose=true after -v (the short alias), got false")
+	}
+}
+
+func TestNameTagOverridesDerivedFlagName(t *testing.T) {
+	opts := &struct {
+		APIKey string `name:"api-key"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"-api-key", "secret"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.APIKey != "secret" {
+		t.Fatalf("APIKey = %q, want %q", opts.APIKey, "secret")
+	}
+}
+
+func TestChoicesTagAcceptsListedValue(t *testing.T) {
+	opts := &struct {
+		Level string `choices:"low|medium|high"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"-level", "medium"}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.Level != "medium" {
+		t.Fatalf("Level = %q, want %q", opts.Level, "medium")
+	}
+}
+
+func TestChoicesTagRejectsUnlistedValue(t *testing.T) {
+	opts := &struct {
+		Level string `choices:"low|medium|high"`
+	}{}
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init([]string{"-level", "extreme"}); err == nil {
+		t.Fatal("expected error for a value not in choices, got nil")
+	}
+}
+
+func TestEnvTagOverridesDerivedEnvVarName(t *testing.T) {
+	opts := &struct {
+		APIKey string `env:"MY_CUSTOM_KEY"`
+	}{}
+	t.Setenv("MY_CUSTOM_KEY", "from-custom-env")
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init(nil); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.APIKey != "from-custom-env" {
+		t.Fatalf("APIKey = %q, want %q", opts.APIKey, "from-custom-env")
+	}
+}
+
+func TestEnvTagMultipleNamesFirstMatchWins(t *testing.T) {
+	opts := &struct {
+		APIKey string `env:"FIRST_KEY,SECOND_KEY"`
+	}{}
+	t.Setenv("SECOND_KEY", "from-second")
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init(nil); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.APIKey != "from-second" {
+		t.Fatalf("APIKey = %q, want %q", opts.APIKey, "from-second")
+	}
+}
+
+func TestWithoutEnvTagNameIsDerivedFromFieldName(t *testing.T) {
+	opts := &struct {
+		APIKey string
+	}{}
+	t.Setenv("API_KEY", "from-derived-env")
+	cmd := Cmd{Name: "test", Opts: opts}
+	if err := cmd.Init(nil); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if opts.APIKey != "from-derived-env" {
+		t.Fatalf("APIKey = %q, want %q", opts.APIKey, "from-derived-env")
+	}
+}